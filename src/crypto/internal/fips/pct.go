@@ -0,0 +1,20 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fips
+
+// PCT runs f as a FIPS 140-3 Pairwise Consistency Test. Unlike CAST, which
+// runs once at module startup and fails closed on error, PCT is run by
+// callers immediately after generating an asymmetric key pair, and its
+// result is returned to them so the freshly generated key can be discarded
+// rather than the whole module failing closed.
+//
+// PCT is a no-op, always returning nil, when the module is not running in
+// FIPS mode.
+func PCT(name string, f func() error) error {
+	if !Enabled() {
+		return nil
+	}
+	return f()
+}
@@ -227,7 +227,7 @@ var fipsSelfTest = sync.OnceFunc(func() {
 			0xe3, 0xb1, 0xa1, 0x5d, 0x8b, 0xeb, 0xe6, 0xae,
 			0x02, 0xb8, 0x76, 0x47, 0x76, 0x11, 0x61, 0x2b,
 		}
-		sig, err := signPKCS1v15(k, "SHA-256", hash)
+		sig, err := signPKCS1v15(nil, k, "SHA-256", hash)
 		if err != nil {
 			return err
 		}
@@ -239,4 +239,141 @@ var fipsSelfTest = sync.OnceFunc(func() {
 		}
 		return nil
 	})
+
+	fips.CAST("RSASSA-PSS 2048-bit sign and verify", func() error {
+		k := testPrivateKey()
+		hashed := []byte{
+			0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+			0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+			0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+			0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+		}
+		want := pssWant
+		sig, err := signPSS(bytes.NewReader(pssSalt), nil, k, "SHA-256", hashed, len(pssSalt))
+		if err != nil {
+			return err
+		}
+		if err := verifyPSS(k.PublicKey(), "SHA-256", hashed, sig); err != nil {
+			return err
+		}
+		if !bytes.Equal(sig, want) {
+			return errors.New("unexpected result")
+		}
+		return nil
+	})
+
+	fips.CAST("RSAES-OAEP 2048-bit encrypt and decrypt", func() error {
+		k := testPrivateKey()
+		msg := []byte{
+			0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+			0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+			0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+			0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+		}
+		label := []byte{}
+		want := oaepWant
+		ct, err := encryptOAEP(bytes.NewReader(oaepSeed), k.PublicKey(), "SHA-256", msg, label)
+		if err != nil {
+			return err
+		}
+		pt, err := decryptOAEP(nil, k, "SHA-256", ct, label)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(pt, msg) {
+			return errors.New("unexpected result")
+		}
+		if !bytes.Equal(ct, want) {
+			return errors.New("unexpected result")
+		}
+		return nil
+	})
 })
+
+// pssSalt is the fixed PSS salt used by the RSASSA-PSS CAST above, so that
+// the resulting signature is reproducible across runs.
+var pssSalt = []byte{
+	0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5,
+	0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5,
+	0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5,
+	0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5,
+}
+
+var pssWant = []byte{
+	0x31, 0xbc, 0x2c, 0x45, 0xf3, 0x4c, 0x2b, 0x44,
+	0x37, 0x1b, 0x3b, 0xba, 0xee, 0x3a, 0x72, 0x68,
+	0x18, 0x6c, 0x48, 0x64, 0xf7, 0xbf, 0x81, 0x03,
+	0x87, 0x92, 0x11, 0x5b, 0x6e, 0x00, 0x03, 0x19,
+	0x0e, 0x6c, 0x47, 0x4c, 0xad, 0xae, 0x12, 0x2f,
+	0xb0, 0x1b, 0xf2, 0x11, 0xdd, 0x9a, 0xc0, 0xdb,
+	0x1f, 0xd7, 0x24, 0x7d, 0xa4, 0xf9, 0x89, 0x85,
+	0xeb, 0xa0, 0x93, 0xdf, 0xe8, 0x6b, 0x0d, 0xec,
+	0xcb, 0xbe, 0x10, 0x07, 0xd0, 0x82, 0x41, 0xdf,
+	0xa3, 0xe2, 0x3d, 0x09, 0x28, 0xb9, 0x7d, 0x46,
+	0xeb, 0x31, 0xf7, 0x65, 0xef, 0xdb, 0xd8, 0x04,
+	0xf3, 0x27, 0x03, 0xae, 0xdf, 0xbd, 0x74, 0x34,
+	0x01, 0x34, 0x4b, 0x0e, 0xb9, 0x56, 0x30, 0xfc,
+	0xec, 0x7f, 0x42, 0xb7, 0x3a, 0x0e, 0xb8, 0xbf,
+	0x2e, 0x2e, 0xc1, 0x8f, 0xa1, 0xd6, 0x0a, 0xc2,
+	0x6d, 0xb5, 0xfc, 0xe5, 0x57, 0x09, 0xfd, 0x6a,
+	0xcd, 0x38, 0x32, 0x59, 0x65, 0xaf, 0x90, 0x90,
+	0xb2, 0xe3, 0x74, 0xcc, 0x80, 0x75, 0x4b, 0x96,
+	0xb0, 0x43, 0x05, 0x43, 0xbe, 0xd9, 0x11, 0x87,
+	0x3a, 0x39, 0xe7, 0xdd, 0x3e, 0x5c, 0x92, 0xc0,
+	0x04, 0x8a, 0x51, 0xfc, 0xf8, 0xe5, 0xb0, 0x5f,
+	0x92, 0xc4, 0x2d, 0xfe, 0xac, 0x39, 0x81, 0xf5,
+	0x08, 0x10, 0x03, 0xe9, 0x78, 0xb7, 0xbb, 0xb3,
+	0xdd, 0x9b, 0x9a, 0x8b, 0x64, 0xf7, 0xc6, 0x70,
+	0x00, 0x0b, 0x0b, 0x81, 0x4c, 0x20, 0x52, 0x4f,
+	0x2c, 0xf6, 0x2c, 0x69, 0xb7, 0x56, 0xf9, 0x69,
+	0x60, 0x47, 0xfb, 0x5a, 0x22, 0x8a, 0xae, 0x26,
+	0xcc, 0xd5, 0xfb, 0x5c, 0xe2, 0x4b, 0xb0, 0x5f,
+	0x28, 0x98, 0xa9, 0xee, 0xd7, 0x1d, 0x08, 0xe1,
+	0x9b, 0xed, 0xd1, 0x80, 0x0d, 0x80, 0xa9, 0x67,
+	0x88, 0x41, 0x2d, 0x03, 0x93, 0x1d, 0x6a, 0xd6,
+	0x58, 0x95, 0xfe, 0x52, 0xed, 0x6d, 0x4c, 0xcf,
+}
+
+// oaepSeed is the fixed OAEP seed used by the RSAES-OAEP CAST above, so that
+// the resulting ciphertext is reproducible across runs.
+var oaepSeed = []byte{
+	0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a,
+	0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a,
+	0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a,
+	0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a, 0x5a,
+}
+
+var oaepWant = []byte{
+	0x0e, 0xea, 0x9d, 0x74, 0xae, 0x9e, 0x6d, 0x1a,
+	0xbd, 0x5d, 0xf8, 0x8d, 0xbe, 0x3d, 0x3c, 0x76,
+	0xb1, 0x41, 0xb3, 0x32, 0xd0, 0xe8, 0x6b, 0xc9,
+	0x58, 0xe2, 0xcb, 0x4f, 0x39, 0x5b, 0xf0, 0x25,
+	0xcd, 0xb5, 0x3c, 0xaf, 0xc6, 0x8b, 0x80, 0x60,
+	0xb5, 0xd8, 0xad, 0xd9, 0x7a, 0x9e, 0x51, 0x34,
+	0x67, 0x8b, 0x0f, 0x09, 0x21, 0xda, 0xf8, 0x7e,
+	0x85, 0x72, 0xe2, 0x29, 0x31, 0x18, 0xab, 0x4f,
+	0xc3, 0x17, 0x91, 0x9c, 0x10, 0x2a, 0xf2, 0xd5,
+	0xaf, 0x92, 0x9f, 0x5d, 0x97, 0x28, 0x6e, 0x79,
+	0x51, 0x71, 0xa8, 0xfd, 0xa0, 0x4a, 0x3f, 0x6a,
+	0x60, 0x6e, 0x16, 0xa1, 0x68, 0x3e, 0x61, 0x2d,
+	0x91, 0xd3, 0xad, 0x39, 0x19, 0x96, 0x32, 0xaa,
+	0x23, 0xfc, 0xf0, 0x9a, 0xd8, 0x3b, 0xe7, 0xdd,
+	0x32, 0x2c, 0x83, 0x67, 0xbc, 0x90, 0xbe, 0x31,
+	0xdd, 0x0b, 0x0f, 0xad, 0x52, 0x24, 0x9f, 0x44,
+	0x0e, 0xcc, 0x48, 0x70, 0x39, 0x55, 0xf5, 0x97,
+	0x0f, 0xe2, 0xa5, 0x7d, 0x5a, 0xb0, 0x5e, 0xd7,
+	0xe1, 0x70, 0xb4, 0xf4, 0xe9, 0x28, 0x1f, 0x85,
+	0xbb, 0x9b, 0xd8, 0x43, 0x25, 0x62, 0x3b, 0x47,
+	0x11, 0xa5, 0x7c, 0x35, 0x74, 0x24, 0x9a, 0x69,
+	0x95, 0x6f, 0xaa, 0xb6, 0x7a, 0x1d, 0x43, 0xb3,
+	0xe6, 0x15, 0x60, 0x39, 0x59, 0x87, 0x71, 0x8e,
+	0x42, 0x24, 0x84, 0xbb, 0x4c, 0x73, 0x6f, 0x98,
+	0x17, 0x94, 0xc3, 0xaa, 0x66, 0x47, 0xf7, 0xe0,
+	0x0a, 0x66, 0xcb, 0x74, 0xce, 0xfd, 0x0e, 0x23,
+	0x3a, 0xd2, 0x11, 0x82, 0xe1, 0x34, 0x51, 0x1c,
+	0xac, 0x38, 0x9c, 0x97, 0xb6, 0xe9, 0x0e, 0xa6,
+	0x94, 0x65, 0xd9, 0x6d, 0x18, 0x0e, 0x46, 0x7d,
+	0xf5, 0xc6, 0xc3, 0xbd, 0x22, 0x15, 0xa5, 0xa3,
+	0x0e, 0x77, 0xd7, 0x6f, 0x51, 0x66, 0x87, 0xe2,
+	0xe1, 0x9e, 0x00, 0xae, 0xdf, 0x11, 0x77, 0x7d,
+}
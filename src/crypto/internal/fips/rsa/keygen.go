@@ -0,0 +1,182 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsa
+
+import (
+	"crypto/internal/fips"
+	"crypto/internal/fips/bigmod"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// GenerateKey generates a random two-prime RSA private key of the given bit
+// size.
+//
+// Most applications should use [crypto/rand.Reader] as random. Note that the
+// returned key does not depend deterministically on the bytes read from
+// random, and may change between calls and/or between versions.
+//
+// FIPS 140-3 IG 10.3.A requires a Pairwise Consistency Test immediately
+// after generating an asymmetric key pair, in addition to the power-on CAST
+// performed once in fipsSelfTest. If that test fails, an error is returned
+// instead of the broken key, and the key is discarded with zero: dP and dQ
+// are overwritten, while d, p, q and qInv are dropped but not overwritten.
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	priv, err := generateKeyHook(random, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fips.PCT("RSA sign/verify PCT", func() error {
+		return pairwiseConsistencyTest(priv)
+	}); err != nil {
+		priv.zero()
+		return nil, err
+	}
+
+	return priv, nil
+}
+
+// generateKeyHook is generateKey, indirected through a variable so tests can
+// stub it to return a deliberately corrupted key and exercise GenerateKey's
+// PCT failure path without waiting on the prime search.
+var generateKeyHook = generateKey
+
+func generateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < 2048 {
+		return nil, errors.New("crypto/internal/fips/rsa: key too small for FIPS 140-3")
+	}
+
+	e := big.NewInt(65537)
+	one := big.NewInt(1)
+
+	var p, q, n, d, dP, dQ, qInv *big.Int
+	for {
+		var err error
+		p, err = rand.Prime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err = rand.Prime(random, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		pMinus1 := new(big.Int).Sub(p, one)
+		qMinus1 := new(big.Int).Sub(q, one)
+		phi := new(big.Int).Mul(pMinus1, qMinus1)
+
+		if new(big.Int).GCD(nil, nil, e, pMinus1).Cmp(one) != 0 {
+			continue
+		}
+		if new(big.Int).GCD(nil, nil, e, qMinus1).Cmp(one) != 0 {
+			continue
+		}
+
+		n = new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+
+		d = new(big.Int).ModInverse(e, phi)
+		if d == nil {
+			continue
+		}
+		qInv = new(big.Int).ModInverse(q, p)
+		if qInv == nil {
+			continue
+		}
+		dP = new(big.Int).Mod(d, pMinus1)
+		dQ = new(big.Int).Mod(d, qMinus1)
+		break
+	}
+
+	N, err := bigmod.NewModulus(n.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	P, err := bigmod.NewModulus(p.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	Q, err := bigmod.NewModulus(q.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	dNat, err := bigmod.NewNat().SetBytes(d.Bytes(), N)
+	if err != nil {
+		return nil, err
+	}
+	qInvNat, err := bigmod.NewNat().SetBytes(qInv.Bytes(), P)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		pub:  PublicKey{N: N, E: 65537},
+		d:    dNat,
+		p:    P,
+		q:    Q,
+		qInv: qInvNat,
+		dP:   dP.Bytes(),
+		dQ:   dQ.Bytes(),
+	}, nil
+}
+
+// zero dereferences priv's key material, so that a key that failed its
+// pairwise consistency test cannot accidentally be used. It also overwrites
+// dP and dQ, the only fields stored as plain byte slices; the bigmod-backed
+// d, p, q and qInv are only dropped, not scrubbed, since bigmod exposes no
+// way to wipe their backing arrays, and the old bytes remain live until GC
+// reclaims them.
+func (priv *PrivateKey) zero() {
+	for i := range priv.dP {
+		priv.dP[i] = 0
+	}
+	for i := range priv.dQ {
+		priv.dQ[i] = 0
+	}
+	*priv = PrivateKey{}
+}
+
+// pairwiseConsistencyTest is the FIPS 140-3 IG 10.3.A Pairwise Consistency
+// Test: it signs and verifies a fixed message with PKCS1v15, and encrypts
+// and decrypts a fixed message with raw RSA, failing closed if priv is
+// internally inconsistent (for example, if dP, dQ or qInv were corrupted).
+// Both operations are computed via CRT, so this does not exercise d.
+func pairwiseConsistencyTest(priv *PrivateKey) error {
+	hashed := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	sig, err := signPKCS1v15(nil, priv, "SHA-256", hashed)
+	if err != nil {
+		return err
+	}
+	if err := verifyPKCS1v15(priv.PublicKey(), "SHA-256", hashed, sig); err != nil {
+		return err
+	}
+
+	m, err := bigmod.NewNat().SetBytes(hashed, priv.pub.N)
+	if err != nil {
+		return err
+	}
+	c := encrypt(priv.PublicKey(), m)
+	m2, err := decrypt(priv, c)
+	if err != nil {
+		return err
+	}
+	if string(m.Bytes(priv.pub.N)) != string(m2.Bytes(priv.pub.N)) {
+		return errors.New("crypto/internal/fips/rsa: pairwise consistency test failed")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsa
+
+import (
+	"crypto/internal/fips"
+	"crypto/internal/fips/bigmod"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+// TestGenerateKey exercises the actual prime search, CRT parameter
+// derivation, and fips.PCT wiring in GenerateKey and generateKey — unlike
+// the TestPairwiseConsistencyTest* tests below, which only ever call
+// pairwiseConsistencyTest on the hardcoded testPrivateKey fixture.
+func TestGenerateKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	hashed := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	sig, err := signPKCS1v15(rand.Reader, priv, "SHA-256", hashed)
+	if err != nil {
+		t.Fatalf("signPKCS1v15 failed on a generated key: %v", err)
+	}
+	if err := verifyPKCS1v15(priv.PublicKey(), "SHA-256", hashed, sig); err != nil {
+		t.Fatalf("verifyPKCS1v15 failed on a generated key's own signature: %v", err)
+	}
+
+	msg := []byte("pairwise consistency")
+	label := []byte{}
+	ct, err := encryptOAEP(rand.Reader, priv.PublicKey(), "SHA-256", msg, label)
+	if err != nil {
+		t.Fatalf("encryptOAEP failed on a generated key: %v", err)
+	}
+	pt, err := decryptOAEP(rand.Reader, priv, "SHA-256", ct, label)
+	if err != nil {
+		t.Fatalf("decryptOAEP failed on a generated key: %v", err)
+	}
+	if string(pt) != string(msg) {
+		t.Fatalf("decryptOAEP round-trip mismatch: got %x, want %x", pt, msg)
+	}
+}
+
+// TestGenerateKeyPCTFailure stubs generateKeyHook to hand back a freshly
+// generated key with a corrupted dP, and confirms GenerateKey itself
+// reports an error rather than returning the broken key. fips.PCT is a
+// no-op outside FIPS mode, so this only exercises the failure path when the
+// module under test has FIPS mode enabled.
+func TestGenerateKeyPCTFailure(t *testing.T) {
+	if !fips.Enabled() {
+		t.Skip("FIPS mode is not enabled; fips.PCT is a no-op and never runs pairwiseConsistencyTest")
+	}
+
+	orig := generateKeyHook
+	defer func() { generateKeyHook = orig }()
+	generateKeyHook = func(random io.Reader, bits int) (*PrivateKey, error) {
+		priv, err := orig(random, bits)
+		if err != nil {
+			return nil, err
+		}
+		corrupt := append([]byte(nil), priv.dP...)
+		corrupt[len(corrupt)-1] ^= 1
+		priv.dP = corrupt
+		return priv, nil
+	}
+
+	if _, err := GenerateKey(rand.Reader, 2048); err == nil {
+		t.Fatal("GenerateKey did not report an error for a corrupted key")
+	}
+}
+
+func TestPairwiseConsistencyTest(t *testing.T) {
+	if err := pairwiseConsistencyTest(testPrivateKey()); err != nil {
+		t.Fatalf("pairwiseConsistencyTest failed on a known-good key: %v", err)
+	}
+}
+
+// Signing and the raw-RSA half of pairwiseConsistencyTest both go through
+// decryptBlinded, which only ever dereferences the CRT parameters dP, dQ,
+// qInv, p and q — not the plain private exponent d — so the PCT must be
+// exercised through dP (or dQ/qInv) to actually catch a corrupted key.
+func TestPairwiseConsistencyTestCorruptedDP(t *testing.T) {
+	priv := testPrivateKey()
+	corrupt := append([]byte(nil), priv.dP...)
+	corrupt[len(corrupt)-1] ^= 1
+	priv.dP = corrupt
+
+	if err := pairwiseConsistencyTest(priv); err == nil {
+		t.Fatal("pairwiseConsistencyTest did not catch a corrupted dP")
+	}
+}
+
+func TestPairwiseConsistencyTestCorruptedQInv(t *testing.T) {
+	priv := testPrivateKey()
+	corrupt, err := bigmod.NewNat().SetBytes(priv.qInv.Bytes(priv.p), priv.p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	one, err := bigmod.NewNat().SetBytes([]byte{1}, priv.p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt.Add(one, priv.p)
+	priv.qInv = corrupt
+
+	if err := pairwiseConsistencyTest(priv); err == nil {
+		t.Fatal("pairwiseConsistencyTest did not catch a corrupted qInv")
+	}
+}
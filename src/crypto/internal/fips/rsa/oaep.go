@@ -0,0 +1,119 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsa
+
+import (
+	"crypto/internal/fips/bigmod"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// This file implements encryption and decryption using RSAES-OAEP as
+// specified in RFC 8017, Section 7.1, with the named hash used both to hash
+// the label and as the MGF1 hash.
+
+// encryptOAEP encrypts msg using RSAES-OAEP, as specified in RFC 8017,
+// Section 7.1.1. A seed as long as the hash output is read from random; the FIPS
+// self-test supplies a fixed-content reader so the ciphertext is
+// reproducible.
+func encryptOAEP(random io.Reader, pub *PublicKey, hash string, msg, label []byte) ([]byte, error) {
+	h, err := hashByName(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	k := pub.N.Size()
+	if len(msg) > k-2*h.Size()-2 {
+		return nil, errors.New("crypto/internal/fips/rsa: message too long for RSA key size")
+	}
+
+	h.Write(label)
+	lHash := h.Sum(nil)
+	h.Reset()
+
+	em := make([]byte, k)
+	seed := em[1 : 1+h.Size()]
+	db := em[1+h.Size():]
+
+	copy(db[:h.Size()], lHash)
+	db[len(db)-len(msg)-1] = 1
+	copy(db[len(db)-len(msg):], msg)
+
+	if _, err := io.ReadFull(random, seed); err != nil {
+		return nil, err
+	}
+
+	mgf1XOR(db, h, seed)
+	mgf1XOR(seed, h, db)
+
+	m, err := bigmod.NewNat().SetBytes(em, pub.N)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(pub, m).Bytes(pub.N), nil
+}
+
+// decryptOAEP decrypts ciphertext, which must have been produced by
+// encryptOAEP with the same hash and label, as specified in RFC 8017,
+// Section 7.1.2.
+//
+// rand, if non-nil, is used to blind the private key operation against
+// timing side channels, exactly like signPKCS1v15's rand parameter.
+func decryptOAEP(rand io.Reader, priv *PrivateKey, hash string, ciphertext, label []byte) ([]byte, error) {
+	h, err := hashByName(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	k := priv.pub.N.Size()
+	if len(ciphertext) > k || k < h.Size()*2+2 {
+		return nil, errors.New("crypto/internal/fips/rsa: decryption error")
+	}
+
+	c, err := bigmod.NewNat().SetBytes(ciphertext, priv.pub.N)
+	if err != nil {
+		return nil, errors.New("crypto/internal/fips/rsa: decryption error")
+	}
+	m, err := decryptBlinded(rand, priv, c)
+	if err != nil {
+		return nil, err
+	}
+	em := m.Bytes(priv.pub.N)
+
+	h.Write(label)
+	lHash := h.Sum(nil)
+	h.Reset()
+
+	firstByteIsZero := subtle.ConstantTimeByteEq(em[0], 0)
+
+	seed := em[1 : h.Size()+1]
+	db := em[h.Size()+1:]
+
+	mgf1XOR(seed, h, db)
+	mgf1XOR(db, h, seed)
+
+	lHash2 := db[:h.Size()]
+	lHash2Good := subtle.ConstantTimeCompare(lHash, lHash2)
+
+	// The remainder of the plaintext must be zero or more 0x00, followed by
+	// 0x01, followed by the message. This is checked in constant time to
+	// avoid Manger's attack.
+	var lookingForIndex, index, invalid int
+	lookingForIndex = 1
+	rest := db[h.Size():]
+	for i := 0; i < len(rest); i++ {
+		equals0 := subtle.ConstantTimeByteEq(rest[i], 0)
+		equals1 := subtle.ConstantTimeByteEq(rest[i], 1)
+		index = subtle.ConstantTimeSelect(lookingForIndex&equals1, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(equals1, 0, lookingForIndex)
+		invalid = subtle.ConstantTimeSelect(lookingForIndex&^equals0, 1, invalid)
+	}
+
+	if firstByteIsZero&lHash2Good&^invalid&^lookingForIndex != 1 {
+		return nil, errors.New("crypto/internal/fips/rsa: decryption error")
+	}
+	return rest[index+1:], nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsa
+
+import (
+	"crypto/internal/fips/bigmod"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// This file implements the RSASSA-PKCS1-v1.5 signature scheme, and the raw
+// RSA primitives it and the other schemes in this package build on, as
+// specified in RFC 8017, Sections 8.2 and 5.
+
+// hashPrefixes are the ASN.1 DER prefixes prepended to a digest before
+// signing, as specified in RFC 8017, Section 9.2, Note 1, for the
+// FIPS-approved hash algorithms supported by this package.
+var hashPrefixes = map[string][]byte{
+	"SHA-256": {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+}
+
+func hashPrefix(hash string) ([]byte, error) {
+	prefix, ok := hashPrefixes[hash]
+	if !ok {
+		return nil, errors.New("crypto/internal/fips/rsa: unsupported hash algorithm " + hash)
+	}
+	return prefix, nil
+}
+
+// signPKCS1v15 signs hashed, which must be the output of hash, using
+// RSASSA-PKCS1-v1.5 as specified in RFC 8017, Section 8.2.1.
+//
+// rand, if non-nil, is used to blind the private key operation against
+// timing side channels; it does not affect the resulting signature, which
+// is deterministic. The FIPS self-test passes nil, since it runs before the
+// module's entropy source has necessarily been health-checked.
+func signPKCS1v15(rand io.Reader, priv *PrivateKey, hash string, hashed []byte) ([]byte, error) {
+	prefix, err := hashPrefix(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tLen := len(prefix) + len(hashed)
+	k := priv.pub.N.Size()
+	if k < tLen+11 {
+		return nil, errors.New("crypto/internal/fips/rsa: message too long for RSA key size")
+	}
+
+	// EM = 0x00 || 0x01 || PS || 0x00 || T
+	em := make([]byte, k)
+	em[1] = 1
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:k-len(hashed)], prefix)
+	copy(em[k-len(hashed):], hashed)
+
+	m, err := bigmod.NewNat().SetBytes(em, priv.pub.N)
+	if err != nil {
+		return nil, err
+	}
+	c, err := decryptBlinded(rand, priv, m)
+	if err != nil {
+		return nil, err
+	}
+	return c.Bytes(priv.pub.N), nil
+}
+
+// verifyPKCS1v15 verifies an RSASSA-PKCS1-v1.5 signature, as specified in
+// RFC 8017, Section 8.2.2.
+func verifyPKCS1v15(pub *PublicKey, hash string, hashed, sig []byte) error {
+	prefix, err := hashPrefix(hash)
+	if err != nil {
+		return err
+	}
+
+	tLen := len(prefix) + len(hashed)
+	k := pub.N.Size()
+	if k < tLen+11 || k != len(sig) {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+
+	c, err := bigmod.NewNat().SetBytes(sig, pub.N)
+	if err != nil {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	em := encrypt(pub, c).Bytes(pub.N)
+
+	// EM = 0x00 || 0x01 || PS || 0x00 || T
+	ok := subtle.ConstantTimeByteEq(em[0], 0)
+	ok &= subtle.ConstantTimeByteEq(em[1], 1)
+	ok &= subtle.ConstantTimeCompare(em[k-len(hashed):k], hashed)
+	ok &= subtle.ConstantTimeCompare(em[k-tLen:k-len(hashed)], prefix)
+	ok &= subtle.ConstantTimeByteEq(em[k-tLen-1], 0)
+	for i := 2; i < k-tLen-1; i++ {
+		ok &= subtle.ConstantTimeByteEq(em[i], 0xff)
+	}
+
+	if ok != 1 {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	return nil
+}
+
+// encrypt performs the public-key RSA operation m^e mod N.
+func encrypt(pub *PublicKey, m *bigmod.Nat) *bigmod.Nat {
+	return bigmod.NewNat().ExpShort(m, uint(pub.E), pub.N)
+}
+
+// decrypt performs the private-key RSA operation using the CRT, without
+// blinding. It must only be called on inputs that do not depend on secret
+// data, such as the pairwise consistency test run on a freshly generated key.
+func decrypt(priv *PrivateKey, c *bigmod.Nat) (*bigmod.Nat, error) {
+	return decryptBlinded(nil, priv, c)
+}
+
+// decryptBlinded performs the private-key RSA operation using the CRT, as
+// specified in RFC 8017, Section 5.1.2. If rand is non-nil, the operation is
+// blinded against timing side channels using randomness read from it.
+func decryptBlinded(rand io.Reader, priv *PrivateKey, c *bigmod.Nat) (*bigmod.Nat, error) {
+	N := priv.pub.N
+
+	unblind := func(m *bigmod.Nat) *bigmod.Nat { return m }
+	if rand != nil {
+		blinded, unblindFn, err := blind(rand, priv, c)
+		if err != nil {
+			return nil, err
+		}
+		c, unblind = blinded, unblindFn
+	}
+
+	mP := bigmod.NewNat().Exp(bigmod.NewNat().Mod(c, priv.p), priv.dP, priv.p)
+	mQ := bigmod.NewNat().Exp(bigmod.NewNat().Mod(c, priv.q), priv.dQ, priv.q)
+
+	// m = mQ + q * ((mP - mQ) * qInv mod p)
+	t := mP.Sub(bigmod.NewNat().Mod(mQ, priv.p), priv.p)
+	t.Mul(priv.qInv, priv.p)
+
+	m, err := bigmod.NewNat().SetBytes(t.Bytes(priv.p), N)
+	if err != nil {
+		return nil, err
+	}
+	q, err := bigmod.NewNat().SetBytes(priv.q.Nat().Bytes(priv.q), N)
+	if err != nil {
+		return nil, err
+	}
+	m.Mul(q, N)
+	m.Add(mQ.ExpandFor(N), N)
+
+	// As a defense in depth against CRT computation faults, verify the
+	// result against the public key before returning it, as recommended by
+	// RFC 8017 and done by the equivalent public crypto/rsa package.
+	if encrypt(priv.PublicKey(), m).Equal(c) != 1 {
+		return nil, errors.New("crypto/internal/fips/rsa: CRT decryption consistency check failed")
+	}
+
+	return unblind(m), nil
+}
+
+// blind returns c multiplied by re mod N for a random unit r, along with a
+// function that removes the blinding factor from the result of the
+// private-key operation on the returned value.
+func blind(rand io.Reader, priv *PrivateKey, c *bigmod.Nat) (blinded *bigmod.Nat, unblind func(*bigmod.Nat) *bigmod.Nat, err error) {
+	N := priv.pub.N
+	nBig := new(big.Int).SetBytes(N.Nat().Bytes(N))
+
+	var r, rInv *big.Int
+	for {
+		rBytes := make([]byte, N.Size())
+		if _, err := io.ReadFull(rand, rBytes); err != nil {
+			return nil, nil, err
+		}
+		r = new(big.Int).SetBytes(rBytes)
+		r.Mod(r, nBig)
+		if r.Sign() == 0 {
+			continue
+		}
+		rInv = new(big.Int).ModInverse(r, nBig)
+		if rInv != nil {
+			break
+		}
+	}
+
+	rNat, err := bigmod.NewNat().SetBytes(r.Bytes(), N)
+	if err != nil {
+		return nil, nil, err
+	}
+	rE := encrypt(&priv.pub, rNat)
+	blinded = rE.Mul(c, N)
+
+	unblind = func(m *bigmod.Nat) *bigmod.Nat {
+		rInvNat, err := bigmod.NewNat().SetBytes(rInv.Bytes(), N)
+		if err != nil {
+			// rInv was computed mod N, so this cannot happen.
+			panic("crypto/internal/fips/rsa: internal error: " + err.Error())
+		}
+		return m.Mul(rInvNat, N)
+	}
+	return blinded, unblind, nil
+}
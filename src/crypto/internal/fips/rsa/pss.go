@@ -0,0 +1,211 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsa
+
+import (
+	"bytes"
+	"crypto/internal/fips/bigmod"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+)
+
+// This file implements the RSASSA-PSS signature scheme according to RFC 8017,
+// Section 8.1, for the FIPS-approved hash algorithms used by this module.
+
+// hashByName returns a fresh hash.Hash for the FIPS-approved hash algorithm
+// named name.
+func hashByName(name string) (hash.Hash, error) {
+	switch name {
+	case "SHA-256":
+		return sha256.New(), nil
+	default:
+		return nil, errors.New("crypto/internal/fips/rsa: unsupported hash algorithm " + name)
+	}
+}
+
+// signPSS signs hashed, which must be the output of hash, using RSASSA-PSS as
+// specified in RFC 8017, Section 8.1.1. saltLen bytes of salt are read from
+// saltRand; the FIPS self-test supplies a fixed-content reader so the
+// signature is reproducible.
+//
+// blindRand, if non-nil, is used to blind the private key operation against
+// timing side channels, exactly like signPKCS1v15's rand parameter; it does
+// not affect the resulting signature.
+func signPSS(saltRand io.Reader, blindRand io.Reader, priv *PrivateKey, hash string, hashed []byte, saltLen int) ([]byte, error) {
+	h, err := hashByName(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashed) != h.Size() {
+		return nil, errors.New("crypto/internal/fips/rsa: input must be hashed with given hash")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(saltRand, salt); err != nil {
+		return nil, err
+	}
+
+	emBits := priv.pub.N.BitLen() - 1
+	em, err := emsaPSSEncode(hashed, emBits, salt, h)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := bigmod.NewNat().SetBytes(em, priv.pub.N)
+	if err != nil {
+		return nil, err
+	}
+	c, err := decryptBlinded(blindRand, priv, m)
+	if err != nil {
+		return nil, err
+	}
+	return c.Bytes(priv.pub.N), nil
+}
+
+// verifyPSS verifies an RSASSA-PSS signature, as specified in RFC 8017,
+// Section 8.1.2, where the salt length equals the size of hash.
+func verifyPSS(pub *PublicKey, hash string, hashed, sig []byte) error {
+	h, err := hashByName(hash)
+	if err != nil {
+		return err
+	}
+	if len(sig) != pub.N.Size() {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+
+	c, err := bigmod.NewNat().SetBytes(sig, pub.N)
+	if err != nil {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	em := encrypt(pub, c).Bytes(pub.N)
+
+	emBits := pub.N.BitLen() - 1
+	return emsaPSSVerify(hashed, em, emBits, h.Size(), h)
+}
+
+// emsaPSSEncode implements EMSA-PSS-ENCODE as specified in RFC 8017, Section
+// 9.1.1.
+func emsaPSSEncode(mHash []byte, emBits int, salt []byte, hash hash.Hash) ([]byte, error) {
+	hLen := hash.Size()
+	sLen := len(salt)
+	emLen := (emBits + 7) / 8
+
+	if len(mHash) != hLen {
+		return nil, errors.New("crypto/internal/fips/rsa: input must be hashed with given hash")
+	}
+	if emLen < hLen+sLen+2 {
+		return nil, errors.New("crypto/internal/fips/rsa: message too long for RSA key size")
+	}
+
+	em := make([]byte, emLen)
+	psLen := emLen - sLen - hLen - 2
+	db := em[:psLen+1+sLen]
+	h := em[psLen+1+sLen : emLen-1]
+
+	var prefix [8]byte
+	hash.Write(prefix[:])
+	hash.Write(mHash)
+	hash.Write(salt)
+	h = hash.Sum(h[:0])
+	hash.Reset()
+
+	db[psLen] = 0x01
+	copy(db[psLen+1:], salt)
+
+	mgf1XOR(db, hash, h)
+	db[0] &= 0xff >> (8*emLen - emBits)
+
+	em[emLen-1] = 0xbc
+	return em, nil
+}
+
+// emsaPSSVerify implements EMSA-PSS-VERIFY as specified in RFC 8017, Section
+// 9.1.2, with the salt length fixed to sLen.
+func emsaPSSVerify(mHash, em []byte, emBits, sLen int, hash hash.Hash) error {
+	hLen := hash.Size()
+	emLen := (emBits + 7) / 8
+	if emLen != len(em) {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	if hLen != len(mHash) {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	if emLen < hLen+sLen+2 {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	if em[emLen-1] != 0xbc {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+
+	db := em[:emLen-hLen-1]
+	h := em[emLen-hLen-1 : emLen-1]
+
+	var bitMask byte = 0xff >> (8*emLen - emBits)
+	if em[0] & ^bitMask != 0 {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+
+	mgf1XOR(db, hash, h)
+	db[0] &= bitMask
+
+	psLen := emLen - hLen - sLen - 2
+	for _, e := range db[:psLen] {
+		if e != 0x00 {
+			return errors.New("crypto/internal/fips/rsa: verification error")
+		}
+	}
+	if db[psLen] != 0x01 {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+
+	salt := db[len(db)-sLen:]
+	var prefix [8]byte
+	hash.Write(prefix[:])
+	hash.Write(mHash)
+	hash.Write(salt)
+	h0 := hash.Sum(nil)
+	hash.Reset()
+
+	if !bytes.Equal(h0, h) {
+		return errors.New("crypto/internal/fips/rsa: verification error")
+	}
+	return nil
+}
+
+// mgf1XOR XORs the bytes in out with a mask generated using the MGF1
+// function specified in RFC 8017, Appendix B.2.1.
+func mgf1XOR(out []byte, hash hash.Hash, seed []byte) {
+	var counter [4]byte
+	var digest []byte
+
+	done := 0
+	for done < len(out) {
+		hash.Write(seed)
+		hash.Write(counter[:])
+		digest = hash.Sum(digest[:0])
+		hash.Reset()
+
+		for i := 0; i < len(digest) && done < len(out); i++ {
+			out[done] ^= digest[i]
+			done++
+		}
+		incCounter(&counter)
+	}
+}
+
+func incCounter(c *[4]byte) {
+	if c[3]++; c[3] != 0 {
+		return
+	}
+	if c[2]++; c[2] != 0 {
+		return
+	}
+	if c[1]++; c[1] != 0 {
+		return
+	}
+	c[0]++
+}